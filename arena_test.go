@@ -113,6 +113,8 @@ func testArenaDeepCopyValue(a *Arena) error {
 	if tempSerialized != jsonTest {
 		return fmt.Errorf("initial parsed test JSON does not match\ngot\n%s\nwant\n%s", tempSerialized, jsonTest)
 	}
+	// Parse an independent ground-truth value from scratch, so it isn't affected by reusing p below
+	originalParsedFromScratch := MustParse(jsonTest)
 	// Do a deep copy to preserve the values after the parser is reused
 	shallowCopy := tempValue
 	deepCopy := a.DeepCopyValue(tempValue)
@@ -126,14 +128,12 @@ func testArenaDeepCopyValue(a *Arena) error {
 			return fmt.Errorf("failed reusing parser to parser random JSON: %w\nJSON\n%s", err, mixerJSON)
 		}
 	}
-	// Now check that the deep copy is good and the shallow copy is bad
-	deepCopyJSON := b2s(deepCopy.MarshalTo(nil))
-	if deepCopyJSON != jsonTest {
-		return fmt.Errorf("deep copy JSON does not match\ngot\n%s\nwant\n%s", deepCopyJSON, jsonTest)
+	// Now check that the deep copy is structurally equal to a freshly-parsed value, and the shallow copy is not
+	if !Equal(deepCopy, originalParsedFromScratch) {
+		return fmt.Errorf("deep copy does not match original\ngot\n%s\nwant\n%s", b2s(deepCopy.MarshalTo(nil)), jsonTest)
 	}
-	shallowCopyJSON := b2s(shallowCopy.MarshalTo(nil))
-	if shallowCopyJSON == jsonTest {
-		return fmt.Errorf("shallow copy JSON matches when it should not match!\nshallow_copy\n%s", shallowCopyJSON)
+	if Equal(shallowCopy, originalParsedFromScratch) {
+		return fmt.Errorf("shallow copy matches original when it should not match!\nshallow_copy\n%s", b2s(shallowCopy.MarshalTo(nil)))
 	}
 	return nil
 }
@@ -0,0 +1,89 @@
+package fastjson
+
+// ArrayMergeStrategy controls how Arena.DeepMergeWith combines two array
+// values.
+type ArrayMergeStrategy int
+
+const (
+	// ArrayMergeReplace discards dst's array and uses a deep copy of src's.
+	ArrayMergeReplace ArrayMergeStrategy = iota
+	// ArrayMergeConcat appends a deep copy of src's items after dst's.
+	ArrayMergeConcat
+	// ArrayMergeByIndex merges src and dst element by element, recursing
+	// into matching indexes and appending any extra trailing src elements.
+	ArrayMergeByIndex
+)
+
+// DeepMerge is DeepMergeWith using ArrayMergeReplace for arrays.
+func (a *Arena) DeepMerge(dst, src *Value) *Value {
+	return a.DeepMergeWith(dst, src, ArrayMergeReplace)
+}
+
+// DeepMergeWith performs a deep, arena-allocating merge of src into a copy
+// of dst and returns that copy; dst itself is left unchanged. For objects,
+// keys from src are copied into the result, recursively merging
+// when both sides hold an object at that key; for arrays, strategy
+// controls how src and dst combine; any other value from src replaces
+// the corresponding value in dst. Every value contributed by src is
+// deep-copied into a via DeepCopyValue, so resetting src's parser
+// afterwards cannot corrupt the merged result. A nil dst or src is
+// treated as absent: the merge falls back to a deep copy of whichever
+// side is non-nil.
+func (a *Arena) DeepMergeWith(dst, src *Value, strategy ArrayMergeStrategy) *Value {
+	if dst == nil && src == nil {
+		return nil
+	}
+	if src == nil {
+		return a.DeepCopyValue(dst)
+	}
+	if dst == nil {
+		return a.DeepCopyValue(src)
+	}
+	if dst.Type() == TypeArray && src.Type() == TypeArray {
+		return a.mergeArrays(dst, src, strategy)
+	}
+	if dst.Type() != TypeObject || src.Type() != TypeObject {
+		return a.DeepCopyValue(src)
+	}
+
+	merged := a.DeepCopyValue(dst)
+	o := merged.GetObject()
+	src.GetObject().Visit(func(key []byte, sv *Value) {
+		k := string(key)
+		dv := o.Get(k)
+		if dv != nil && ((dv.Type() == TypeObject && sv.Type() == TypeObject) || (dv.Type() == TypeArray && sv.Type() == TypeArray)) {
+			o.Set(k, a.DeepMergeWith(dv, sv, strategy))
+			return
+		}
+		o.Set(k, a.DeepCopyValue(sv))
+	})
+	return merged
+}
+
+func (a *Arena) mergeArrays(dst, src *Value, strategy ArrayMergeStrategy) *Value {
+	switch strategy {
+	case ArrayMergeConcat:
+		merged := a.DeepCopyValue(dst)
+		n := len(merged.GetArray())
+		for i, sv := range src.GetArray() {
+			merged.SetArrayItem(n+i, a.DeepCopyValue(sv))
+		}
+		return merged
+	case ArrayMergeByIndex:
+		da, sa := dst.GetArray(), src.GetArray()
+		merged := a.DeepCopyValue(dst)
+		if len(sa) > len(da) {
+			merged.SetArrayLength(len(sa))
+		}
+		for i, sv := range sa {
+			if i < len(da) {
+				merged.SetArrayItem(i, a.DeepMergeWith(da[i], sv, strategy))
+			} else {
+				merged.SetArrayItem(i, a.DeepCopyValue(sv))
+			}
+		}
+		return merged
+	default: // ArrayMergeReplace
+		return a.DeepCopyValue(src)
+	}
+}
@@ -0,0 +1,82 @@
+package fastjson
+
+import (
+	"testing"
+)
+
+func TestArenaDeepMergeObjects(t *testing.T) {
+	var a Arena
+	dst := MustParse(`{"a":1,"b":{"x":1,"y":2}}`)
+	src := MustParse(`{"b":{"y":3,"z":4},"c":5}`)
+
+	merged := a.DeepMerge(dst, src)
+	str := merged.String()
+	strExpected := `{"a":1,"b":{"x":1,"y":3,"z":4},"c":5}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestArenaDeepMergeWithArrayStrategies(t *testing.T) {
+	var a Arena
+	dst := MustParse(`{"a":[1,2]}`)
+
+	replaced := a.DeepMergeWith(dst, MustParse(`{"a":[3]}`), ArrayMergeReplace)
+	if str := replaced.String(); str != `{"a":[3]}` {
+		t.Fatalf("ArrayMergeReplace: unexpected result %q", str)
+	}
+
+	concatenated := a.DeepMergeWith(dst, MustParse(`{"a":[3,4]}`), ArrayMergeConcat)
+	if str := concatenated.String(); str != `{"a":[1,2,3,4]}` {
+		t.Fatalf("ArrayMergeConcat: unexpected result %q", str)
+	}
+
+	byIndex := a.DeepMergeWith(dst, MustParse(`{"a":[10,20,30]}`), ArrayMergeByIndex)
+	if str := byIndex.String(); str != `{"a":[10,20,30]}` {
+		t.Fatalf("ArrayMergeByIndex: unexpected result %q", str)
+	}
+}
+
+func TestArenaDeepMergeNilSide(t *testing.T) {
+	var a Arena
+	src := MustParse(`{"a":1}`)
+
+	if str := a.DeepMerge(nil, src).String(); str != `{"a":1}` {
+		t.Fatalf("nil dst: unexpected result %q", str)
+	}
+
+	dst := MustParse(`{"a":1}`)
+	if str := a.DeepMerge(dst, nil).String(); str != `{"a":1}` {
+		t.Fatalf("nil src: unexpected result %q", str)
+	}
+
+	if merged := a.DeepMerge(nil, nil); merged != nil {
+		t.Fatalf("nil dst and src: expected nil, got %q", merged.String())
+	}
+}
+
+func TestArenaDeepMergeSurvivesSourceParserReset(t *testing.T) {
+	var a Arena
+	// dst gets its own parser: parsing src with p must not trash dst.
+	var pd, p Parser
+
+	dst, err := pd.Parse(`{"a":1}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := p.Parse(`{"b":2}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	merged := a.DeepMerge(dst, src)
+	if _, err := p.Parse(`{"garbage":"xxxxxxxxxxxxxxxxxxxxxxxxxxxx"}`); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	str := merged.String()
+	strExpected := `{"a":1,"b":2}`
+	if str != strExpected {
+		t.Fatalf("unexpected result after parser reuse\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
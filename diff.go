@@ -0,0 +1,165 @@
+package fastjson
+
+import (
+	"fmt"
+	"math"
+)
+
+// DiffKind describes how two values differ at a given path, as reported by
+// Diff.
+type DiffKind int
+
+const (
+	// Added means the path exists in b but not in a.
+	Added DiffKind = iota
+	// Removed means the path exists in a but not in b.
+	Removed
+	// Changed means the path exists in both, but the values differ.
+	Changed
+)
+
+// String returns a human-readable name for k.
+func (k DiffKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffEntry describes a single structural difference found by Diff.
+type DiffEntry struct {
+	// Path is the RFC 6901 JSON Pointer to the differing value, relative
+	// to the values originally passed to Diff.
+	Path string
+	Kind DiffKind
+	// A is the value on the a side, or nil if Kind is Added.
+	A *Value
+	// B is the value on the b side, or nil if Kind is Removed.
+	B *Value
+}
+
+// Equal reports whether a and b are structurally equal.
+//
+// Object comparison is key-set based and order-independent (on duplicate
+// keys, the last value wins, matching parsing); array comparison is
+// order-sensitive; numbers are compared by parsed float64 value; strings
+// are compared after JSON unescaping.
+func Equal(a, b *Value) bool {
+	return len(Diff(a, b)) == 0
+}
+
+// EqualEpsilon is like Equal, but two numbers are considered equal as long
+// as they differ by no more than epsilon.
+func EqualEpsilon(a, b *Value, epsilon float64) bool {
+	return len(DiffEpsilon(a, b, epsilon)) == 0
+}
+
+// Diff returns the structural differences between a and b, each anchored
+// to the RFC 6901 JSON Pointer path where it was found. A nil slice means
+// a and b are structurally equal.
+func Diff(a, b *Value) []DiffEntry {
+	return DiffEpsilon(a, b, 0)
+}
+
+// DiffEpsilon is like Diff, but two numbers are considered equal as long as
+// they differ by no more than epsilon.
+func DiffEpsilon(a, b *Value, epsilon float64) []DiffEntry {
+	var entries []DiffEntry
+	diffValues("", a, b, epsilon, &entries)
+	return entries
+}
+
+func diffValues(path string, a, b *Value, epsilon float64, entries *[]DiffEntry) {
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return
+		case a == nil:
+			*entries = append(*entries, DiffEntry{Path: path, Kind: Added, B: b})
+		default:
+			*entries = append(*entries, DiffEntry{Path: path, Kind: Removed, A: a})
+		}
+		return
+	}
+	at, bt := a.Type(), b.Type()
+	if at != bt {
+		*entries = append(*entries, DiffEntry{Path: path, Kind: Changed, A: a, B: b})
+		return
+	}
+	switch at {
+	case TypeObject:
+		diffObjects(path, a.GetObject(), b.GetObject(), epsilon, entries)
+	case TypeArray:
+		diffArrays(path, a.GetArray(), b.GetArray(), epsilon, entries)
+	case TypeString:
+		if string(a.GetStringBytes()) != string(b.GetStringBytes()) {
+			*entries = append(*entries, DiffEntry{Path: path, Kind: Changed, A: a, B: b})
+		}
+	case TypeNumber:
+		af, _ := a.Float64()
+		bf, _ := b.Float64()
+		if math.Abs(af-bf) > epsilon {
+			*entries = append(*entries, DiffEntry{Path: path, Kind: Changed, A: a, B: b})
+		}
+	default:
+		// TypeNull, TypeTrue, TypeFalse: equal Type() is sufficient.
+	}
+}
+
+func diffObjects(path string, a, b *Object, epsilon float64, entries *[]DiffEntry) {
+	// Resolve each key to its last value; Object.Get returns the first.
+	aLast := make(map[string]*Value, a.Len())
+	a.Visit(func(key []byte, v *Value) { aLast[string(key)] = v })
+	bLast := make(map[string]*Value, b.Len())
+	b.Visit(func(key []byte, v *Value) { bLast[string(key)] = v })
+
+	seen := make(map[string]struct{}, a.Len())
+	a.Visit(func(key []byte, _ *Value) {
+		k := string(key)
+		if _, ok := seen[k]; ok {
+			// Duplicate key: already diffed using its last value.
+			return
+		}
+		seen[k] = struct{}{}
+		p := path + "/" + escapePointerToken(k)
+		av := aLast[k]
+		bv := bLast[k]
+		if bv == nil {
+			*entries = append(*entries, DiffEntry{Path: p, Kind: Removed, A: av})
+			return
+		}
+		diffValues(p, av, bv, epsilon, entries)
+	})
+	b.Visit(func(key []byte, _ *Value) {
+		k := string(key)
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		*entries = append(*entries, DiffEntry{Path: path + "/" + escapePointerToken(k), Kind: Added, B: bLast[k]})
+	})
+}
+
+func diffArrays(path string, a, b []*Value, epsilon float64, entries *[]DiffEntry) {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		p := fmt.Sprintf("%s/%d", path, i)
+		switch {
+		case i >= len(a):
+			*entries = append(*entries, DiffEntry{Path: p, Kind: Added, B: b[i]})
+		case i >= len(b):
+			*entries = append(*entries, DiffEntry{Path: p, Kind: Removed, A: a[i]})
+		default:
+			diffValues(p, a[i], b[i], epsilon, entries)
+		}
+	}
+}
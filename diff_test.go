@@ -0,0 +1,130 @@
+package fastjson
+
+import (
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	equalCases := []struct {
+		a, b string
+	}{
+		{`{"a":1,"b":2}`, `{"b":2,"a":1}`},
+		{`[1,2,3]`, `[1,2,3]`},
+		{`1.0`, `1`},
+		{`"fo\no"`, `"fo\no"`},
+		{`null`, `null`},
+	}
+	for _, c := range equalCases {
+		a, b := MustParse(c.a), MustParse(c.b)
+		if !Equal(a, b) {
+			t.Fatalf("expected %s to equal %s; diff: %v", c.a, c.b, Diff(a, b))
+		}
+	}
+
+	unequalCases := []struct {
+		a, b string
+	}{
+		{`{"a":1}`, `{"a":2}`},
+		{`{"a":1}`, `{"a":1,"b":2}`},
+		{`[1,2,3]`, `[1,3,2]`},
+		{`[1,2]`, `[1,2,3]`},
+		{`"foo"`, `"bar"`},
+		{`1`, `"1"`},
+	}
+	for _, c := range unequalCases {
+		a, b := MustParse(c.a), MustParse(c.b)
+		if Equal(a, b) {
+			t.Fatalf("expected %s to not equal %s", c.a, c.b)
+		}
+	}
+}
+
+func TestEqualEpsilon(t *testing.T) {
+	a := MustParse(`1.0`)
+	b := MustParse(`1.0000001`)
+	if Equal(a, b) {
+		t.Fatalf("expected exact comparison to differ")
+	}
+	if !EqualEpsilon(a, b, 0.001) {
+		t.Fatalf("expected epsilon comparison to consider them equal")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := MustParse(`{"a":1,"b":{"c":2},"d":[1,2]}`)
+	b := MustParse(`{"a":2,"b":{"c":2},"d":[1,2,3],"e":"new"}`)
+
+	entries := Diff(a, b)
+	byPath := make(map[string]DiffEntry, len(entries))
+	for _, e := range entries {
+		byPath[e.Path] = e
+	}
+
+	if e, ok := byPath["/a"]; !ok || e.Kind != Changed {
+		t.Fatalf("expected /a to be reported as changed; got %+v", byPath["/a"])
+	}
+	if e, ok := byPath["/d/2"]; !ok || e.Kind != Added {
+		t.Fatalf("expected /d/2 to be reported as added; got %+v", byPath["/d/2"])
+	}
+	if e, ok := byPath["/e"]; !ok || e.Kind != Added {
+		t.Fatalf("expected /e to be reported as added; got %+v", byPath["/e"])
+	}
+	if _, ok := byPath["/b/c"]; ok {
+		t.Fatalf("did not expect a diff entry for unchanged /b/c")
+	}
+	if len(entries) != 3 {
+		t.Fatalf("unexpected number of diff entries; got %d; want %d: %+v", len(entries), 3, entries)
+	}
+}
+
+func TestEqualNilValue(t *testing.T) {
+	v := MustParse(`{"a":1}`)
+
+	if Equal(v.Get("missing"), v.Get("also_missing")) != true {
+		t.Fatalf("expected two nil Value.Get results to be equal")
+	}
+	if Equal(v.Get("a"), v.Get("missing")) {
+		t.Fatalf("expected a present value and a missing value to differ")
+	}
+	if Equal(v.Get("missing"), v.Get("a")) {
+		t.Fatalf("expected a missing value and a present value to differ")
+	}
+}
+
+func TestDiffRemoved(t *testing.T) {
+	a := MustParse(`{"a":1,"b":2}`)
+	b := MustParse(`{"a":1}`)
+
+	entries := Diff(a, b)
+	if len(entries) != 1 || entries[0].Path != "/b" || entries[0].Kind != Removed {
+		t.Fatalf("unexpected diff entries: %+v", entries)
+	}
+}
+
+func TestEqualDuplicateKeys(t *testing.T) {
+	a := MustParse(`{"x":1,"x":2}`)
+	b := MustParse(`{"x":2}`)
+
+	if !Equal(a, b) {
+		t.Fatalf("expected a's last value for a duplicate key to match b; diff: %v", Diff(a, b))
+	}
+
+	c := MustParse(`{"x":1,"x":2}`)
+	d := MustParse(`{"x":1}`)
+	if Equal(c, d) {
+		t.Fatalf("expected a's last value for a duplicate key to differ from b's non-last value")
+	}
+}
+
+func TestDiffBOnlyDuplicateKey(t *testing.T) {
+	a := MustParse(`{}`)
+	b := MustParse(`{"y":1,"y":2}`)
+
+	entries := Diff(a, b)
+	if len(entries) != 1 || entries[0].Path != "/y" || entries[0].Kind != Added {
+		t.Fatalf("unexpected diff entries: %+v", entries)
+	}
+	if got, _ := entries[0].B.Float64(); got != 2 {
+		t.Fatalf("expected the added entry to report b's last value for the duplicate key; got %v", got)
+	}
+}
@@ -0,0 +1,33 @@
+package fastjson
+
+// MergePatch applies an RFC 7396 JSON Merge Patch to v and returns the
+// merged result.
+//
+// If patch is not an object, it replaces v entirely. Otherwise, for each
+// key in patch: a null value deletes that key from the result, and any
+// other value recursively merge-patches into the corresponding key,
+// creating an object from a when that key is absent or not itself an
+// object in v. Every value contributed by the result is deep-copied into
+// a via DeepCopyValue, so the merged value stays valid independent of
+// patch's own arena or parser.
+func (v *Value) MergePatch(patch *Value, a *Arena) *Value {
+	if patch.Type() != TypeObject {
+		return a.DeepCopyValue(patch)
+	}
+
+	dst := a.NewObject()
+	if v != nil && v.Type() == TypeObject {
+		dst = a.DeepCopyValue(v)
+	}
+	o := dst.GetObject()
+
+	patch.GetObject().Visit(func(key []byte, pv *Value) {
+		k := string(key)
+		if pv.Type() == TypeNull {
+			o.Del(k)
+			return
+		}
+		o.Set(k, o.Get(k).MergePatch(pv, a))
+	})
+	return dst
+}
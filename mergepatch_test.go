@@ -0,0 +1,85 @@
+package fastjson
+
+import (
+	"testing"
+)
+
+func TestMergePatchObject(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":"b","c":{"d":"e","f":"g"}}`)
+	patch := MustParse(`{"a":"z","c":{"f":null}}`)
+
+	merged := v.MergePatch(patch, &a)
+	str := merged.String()
+	strExpected := `{"a":"z","c":{"d":"e"}}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestMergePatchNewNestedObjectKey(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":1}`)
+	patch := MustParse(`{"b":{"x":1}}`)
+
+	merged := v.MergePatch(patch, &a)
+	str := merged.String()
+	strExpected := `{"a":1,"b":{"x":1}}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestMergePatchReplacesNonObjectTarget(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":[1,2,3]}`)
+	patch := MustParse(`{"a":{"b":"c"}}`)
+
+	merged := v.MergePatch(patch, &a)
+	str := merged.String()
+	strExpected := `{"a":{"b":"c"}}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestMergePatchNonObjectPatchReplacesWholeValue(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":"b"}`)
+	patch := MustParse(`[1,2,3]`)
+
+	merged := v.MergePatch(patch, &a)
+	str := merged.String()
+	strExpected := `[1,2,3]`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestMergePatchSurvivesSourceParserReset(t *testing.T) {
+	var a Arena
+	// v and patch get separate parsers, so parsing patch does not trash v.
+	var p, q Parser
+	v, err := p.Parse(`{"a":"b"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	patch, err := q.Parse(`{"c":"d"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	merged := v.MergePatch(patch, &a)
+	// Reuse both parsers, which would trash shallow references into patch/v.
+	for _, pp := range []*Parser{&p, &q} {
+		if _, err := pp.Parse(`{"garbage":"xxxxxxxxxxxxxxxxxxxxxxxxxxxx"}`); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	str := merged.String()
+	strExpected := `{"a":"b","c":"d"}`
+	if str != strExpected {
+		t.Fatalf("unexpected result after parser reuse\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
@@ -0,0 +1,202 @@
+package fastjson
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ApplyPatch applies an RFC 6902 JSON Patch to v, mutating it in place and
+// returning v.
+//
+// patch must be a JSON array of patch operation objects, each of the form
+// {"op": "...", "path": "...", ...}, as defined by RFC 6902. Paths are
+// resolved as RFC 6901 JSON Pointers. Any "value" supplied by an add or
+// replace op is deep-copied into a, so the result stays valid
+// for the lifetime of a independent of where that value came from.
+//
+// If any operation in the patch fails, v is rolled back to its pre-patch
+// state before the error is returned, so a failed ApplyPatch call is a
+// no-op from the caller's point of view.
+func ApplyPatch(v *Value, patch *Value, a *Arena) (*Value, error) {
+	ops, err := patch.Array()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse patch as an array: %w", err)
+	}
+
+	backup := a.DeepCopyValue(v)
+	for i, opv := range ops {
+		if err := applyPatchOp(v, opv, a); err != nil {
+			*v = *backup
+			return nil, fmt.Errorf("patch op #%d: %w", i, err)
+		}
+	}
+	return v, nil
+}
+
+// MustApplyPatch is like ApplyPatch, but panics on error instead of
+// returning it.
+func MustApplyPatch(v *Value, patch *Value, a *Arena) *Value {
+	v, err := ApplyPatch(v, patch, a)
+	if err != nil {
+		panic(fmt.Sprintf("fastjson.MustApplyPatch: %s", err))
+	}
+	return v
+}
+
+func applyPatchOp(v *Value, opv *Value, a *Arena) error {
+	op := string(opv.GetStringBytes("op"))
+	path := string(opv.GetStringBytes("path"))
+	tokens, err := splitJSONPointer(path)
+	if err != nil {
+		return fmt.Errorf("invalid path %q: %w", path, err)
+	}
+
+	switch op {
+	case "add":
+		return patchAdd(v, tokens, opv.Get("value"), a)
+	case "remove":
+		return patchRemove(v, tokens)
+	case "replace":
+		return patchReplace(v, tokens, opv.Get("value"), a)
+	case "move":
+		from := string(opv.GetStringBytes("from"))
+		fromTokens, err := splitJSONPointer(from)
+		if err != nil {
+			return fmt.Errorf("invalid from %q: %w", from, err)
+		}
+		moved := v.Get(fromTokens...)
+		if moved == nil {
+			return fmt.Errorf("move: source path %q does not exist", from)
+		}
+		moved = a.DeepCopyValue(moved)
+		if err := patchRemove(v, fromTokens); err != nil {
+			return err
+		}
+		return patchAdd(v, tokens, moved, a)
+	case "copy":
+		from := string(opv.GetStringBytes("from"))
+		fromTokens, err := splitJSONPointer(from)
+		if err != nil {
+			return fmt.Errorf("invalid from %q: %w", from, err)
+		}
+		copied := v.Get(fromTokens...)
+		if copied == nil {
+			return fmt.Errorf("copy: source path %q does not exist", from)
+		}
+		return patchAdd(v, tokens, a.DeepCopyValue(copied), a)
+	case "test":
+		target := v.Get(tokens...)
+		expected := opv.Get("value")
+		if !Equal(target, expected) {
+			return fmt.Errorf("test: value at %q does not match expected value", path)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported op %q", op)
+	}
+}
+
+// patchAdd implements the RFC 6902 "add" op: unlike Value.Set on an array,
+// it inserts at the given index, shifting later elements up by one. The
+// "-" token appends.
+func patchAdd(v *Value, tokens []string, val *Value, a *Arena) error {
+	if val == nil {
+		return fmt.Errorf("add: missing value")
+	}
+	if len(tokens) == 0 {
+		*v = *a.DeepCopyValue(val)
+		return nil
+	}
+
+	parent := v.Get(tokens[:len(tokens)-1]...)
+	if parent == nil {
+		return fmt.Errorf("add: parent path does not exist")
+	}
+	key := tokens[len(tokens)-1]
+
+	switch parent.Type() {
+	case TypeArray:
+		n := len(parent.GetArray())
+		idx := n
+		if key != "-" {
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i > n {
+				return fmt.Errorf("add: invalid array index %q", key)
+			}
+			idx = i
+		}
+		parent.SetArrayLength(n + 1)
+		for j := n - 1; j >= idx; j-- {
+			parent.SetArrayItem(j+1, parent.Get(strconv.Itoa(j)))
+		}
+		parent.SetArrayItem(idx, a.DeepCopyValue(val))
+	case TypeObject:
+		parent.Set(key, a.DeepCopyValue(val))
+	default:
+		return fmt.Errorf("add: parent at %q is neither object nor array", key)
+	}
+	return nil
+}
+
+// patchRemove implements the RFC 6902 "remove" op: later array elements
+// shift down by one to close the gap, and unlike Value.Del it returns an
+// error when the addressed index or key does not exist.
+func patchRemove(v *Value, tokens []string) error {
+	if len(tokens) == 0 {
+		return fmt.Errorf("remove: cannot remove the document root")
+	}
+
+	parent := v.Get(tokens[:len(tokens)-1]...)
+	if parent == nil {
+		return fmt.Errorf("remove: parent path does not exist")
+	}
+	key := tokens[len(tokens)-1]
+
+	switch parent.Type() {
+	case TypeArray:
+		n := len(parent.GetArray())
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 || idx >= n {
+			return fmt.Errorf("remove: invalid array index %q", key)
+		}
+		for j := idx; j < n-1; j++ {
+			parent.SetArrayItem(j, parent.Get(strconv.Itoa(j+1)))
+		}
+		parent.SetArrayLength(n - 1)
+	case TypeObject:
+		if parent.Get(key) == nil {
+			return fmt.Errorf("remove: path %q does not exist", key)
+		}
+		parent.Del(key)
+	default:
+		return fmt.Errorf("remove: parent at %q is neither object nor array", key)
+	}
+	return nil
+}
+
+func patchReplace(v *Value, tokens []string, val *Value, a *Arena) error {
+	if val == nil {
+		return fmt.Errorf("replace: missing value")
+	}
+	if len(tokens) == 0 {
+		*v = *a.DeepCopyValue(val)
+		return nil
+	}
+	if v.Get(tokens...) == nil {
+		return fmt.Errorf("replace: path does not exist")
+	}
+
+	parent := v.Get(tokens[:len(tokens)-1]...)
+	if parent == nil {
+		return fmt.Errorf("replace: parent path does not exist")
+	}
+	key := tokens[len(tokens)-1]
+
+	switch parent.Type() {
+	case TypeArray, TypeObject:
+		parent.Set(key, a.DeepCopyValue(val))
+	default:
+		return fmt.Errorf("replace: parent at %q is neither object nor array", key)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package fastjson
+
+import (
+	"testing"
+)
+
+func TestApplyPatchAddReplaceRemove(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":1,"b":[1,2,3]}`)
+	patch := MustParse(`[
+		{"op":"add","path":"/c","value":"new"},
+		{"op":"add","path":"/b/1","value":99},
+		{"op":"replace","path":"/a","value":2},
+		{"op":"remove","path":"/b/0"}
+	]`)
+
+	v, err := ApplyPatch(v, patch, &a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	str := v.String()
+	strExpected := `{"a":2,"b":[99,2,3],"c":"new"}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestApplyPatchAddArrayAppend(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"b":[1,2]}`)
+	patch := MustParse(`[{"op":"add","path":"/b/-","value":3}]`)
+
+	v, err := ApplyPatch(v, patch, &a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	str := v.String()
+	strExpected := `{"b":[1,2,3]}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestApplyPatchMoveAndCopy(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":{"x":1},"b":{}}`)
+	patch := MustParse(`[
+		{"op":"copy","from":"/a/x","path":"/b/x"},
+		{"op":"move","from":"/a","path":"/c"}
+	]`)
+
+	v, err := ApplyPatch(v, patch, &a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	str := v.String()
+	strExpected := `{"b":{"x":1},"c":{"x":1}}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestApplyPatchTest(t *testing.T) {
+	var a Arena
+
+	v := MustParse(`{"a":1}`)
+	patch := MustParse(`[{"op":"test","path":"/a","value":1}]`)
+	if _, err := ApplyPatch(v, patch, &a); err != nil {
+		t.Fatalf("unexpected error for passing test op: %s", err)
+	}
+
+	v = MustParse(`{"a":1}`)
+	patch = MustParse(`[{"op":"test","path":"/a","value":2}]`)
+	if _, err := ApplyPatch(v, patch, &a); err == nil {
+		t.Fatalf("expected error for failing test op")
+	}
+}
+
+func TestApplyPatchTestAgainstMissingPath(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":1}`)
+	patch := MustParse(`[{"op":"test","path":"/missing","value":1}]`)
+	if _, err := ApplyPatch(v, patch, &a); err == nil {
+		t.Fatalf("expected error for test op against a nonexistent path")
+	}
+}
+
+func TestApplyPatchRollsBackOnError(t *testing.T) {
+	var a Arena
+	v := MustParse(`{"a":1,"b":2}`)
+	patch := MustParse(`[
+		{"op":"replace","path":"/a","value":99},
+		{"op":"remove","path":"/does/not/exist"}
+	]`)
+
+	_, err := ApplyPatch(v, patch, &a)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	str := v.String()
+	strExpected := `{"a":1,"b":2}`
+	if str != strExpected {
+		t.Fatalf("expected rollback to pre-patch state\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestApplyPatchSurvivesSourceParserReset(t *testing.T) {
+	var a Arena
+	// v gets its own parser: ApplyPatch mutates v in place, so only the
+	// patch's parser p is reused below.
+	var pv, p Parser
+	v, err := pv.Parse(`{"a":{}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	patch, err := p.Parse(`[{"op":"add","path":"/a/x","value":{"n":1}}]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err = ApplyPatch(v, patch, &a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Reuse the parser, which would trash shallow references into patch.
+	if _, err := p.Parse(`{"garbage":"xxxxxxxxxxxxxxxxxxxxxxxxxxxx"}`); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	str := v.String()
+	strExpected := `{"a":{"x":{"n":1}}}`
+	if str != strExpected {
+		t.Fatalf("unexpected result after parser reuse\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+}
+
+func TestMustApplyPatchPanicsOnError(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	var a Arena
+	v := MustParse(`{"a":1}`)
+	patch := MustParse(`[{"op":"remove","path":"/missing"}]`)
+	MustApplyPatch(v, patch, &a)
+}
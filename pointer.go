@@ -0,0 +1,162 @@
+package fastjson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pointer is a pre-parsed RFC 6901 JSON Pointer.
+//
+// Parsing a pointer string is O(len(pointer)); Pointer lets callers amortize
+// that cost across repeated lookups on the same path.
+type Pointer []string
+
+// ParsePointer parses pointer as an RFC 6901 JSON Pointer.
+//
+// An empty string addresses the whole document. Reference tokens are
+// unescaped per RFC 6901 ("~1" -> "/", then "~0" -> "~").
+func ParsePointer(pointer string) (Pointer, error) {
+	tokens, err := splitJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return Pointer(tokens), nil
+}
+
+// splitJSONPointer splits an RFC 6901 JSON Pointer into its unescaped
+// reference tokens. An empty pointer refers to the whole document and
+// splits to a nil token slice.
+func splitJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("json pointer must be empty or start with '/'")
+	}
+	parts := strings.Split(pointer[1:], "/")
+	for i, p := range parts {
+		parts[i] = unescapePointerToken(p)
+	}
+	return parts, nil
+}
+
+// unescapePointerToken undoes RFC 6901 reference token escaping: "~1"
+// becomes "/" and "~0" becomes "~", in that order.
+func unescapePointerToken(tok string) string {
+	if !strings.Contains(tok, "~") {
+		return tok
+	}
+	tok = strings.ReplaceAll(tok, "~1", "/")
+	tok = strings.ReplaceAll(tok, "~0", "~")
+	return tok
+}
+
+// MustParsePointer is like ParsePointer, but panics on error.
+func MustParsePointer(pointer string) Pointer {
+	p, err := ParsePointer(pointer)
+	if err != nil {
+		panic(fmt.Sprintf("fastjson.MustParsePointer: %s", err))
+	}
+	return p
+}
+
+// Get returns the value addressed by p inside v, or nil if it doesn't exist.
+func (p Pointer) Get(v *Value) *Value {
+	return v.Get(p...)
+}
+
+// Set sets the value addressed by p inside v to newValue.
+//
+// Every path component except the last must already exist. If the
+// addressed container is an array and the last token is "-", newValue is
+// appended, matching the "-" end-of-array token from RFC 6901/6902.
+func (p Pointer) Set(v *Value, newValue *Value) error {
+	return pointerSet(v, p, newValue)
+}
+
+// Del deletes the value addressed by p inside v. It is a no-op if the
+// addressed value doesn't exist or if p is empty (the whole document).
+func (p Pointer) Del(v *Value) {
+	pointerDel(v, p)
+}
+
+// GetPointer returns the value addressed by the RFC 6901 JSON pointer, or
+// nil if pointer is malformed or the addressed value doesn't exist.
+func (v *Value) GetPointer(pointer string) *Value {
+	p, err := ParsePointer(pointer)
+	if err != nil {
+		return nil
+	}
+	return p.Get(v)
+}
+
+// SetPointer sets the value addressed by the RFC 6901 JSON pointer to
+// newValue. See Pointer.Set for the exact semantics.
+func (v *Value) SetPointer(pointer string, newValue *Value) error {
+	p, err := ParsePointer(pointer)
+	if err != nil {
+		return err
+	}
+	return p.Set(v, newValue)
+}
+
+// DelPointer deletes the value addressed by the RFC 6901 JSON pointer.
+//
+// It is a no-op if pointer is malformed or doesn't address an existing
+// value.
+func (v *Value) DelPointer(pointer string) {
+	p, err := ParsePointer(pointer)
+	if err != nil {
+		return
+	}
+	p.Del(v)
+}
+
+func pointerSet(v *Value, tokens Pointer, newValue *Value) error {
+	if len(tokens) == 0 {
+		*v = *newValue
+		return nil
+	}
+	parent := v.Get(tokens[:len(tokens)-1]...)
+	if parent == nil {
+		return fmt.Errorf("fastjson: pointer %q: parent path does not exist", joinPointer(tokens))
+	}
+	key := tokens[len(tokens)-1]
+	if parent.Type() == TypeArray && key == "-" {
+		key = strconv.Itoa(len(parent.GetArray()))
+	}
+	parent.Set(key, newValue)
+	return nil
+}
+
+func pointerDel(v *Value, tokens Pointer) {
+	if len(tokens) == 0 {
+		return
+	}
+	parent := v.Get(tokens[:len(tokens)-1]...)
+	if parent == nil {
+		return
+	}
+	parent.Del(tokens[len(tokens)-1])
+}
+
+func joinPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(escapePointerToken(t))
+	}
+	return sb.String()
+}
+
+// escapePointerToken applies RFC 6901 reference token escaping: "~" becomes
+// "~0" and "/" becomes "~1". It is the inverse of unescapePointerToken.
+func escapePointerToken(tok string) string {
+	if !strings.ContainsAny(tok, "~/") {
+		return tok
+	}
+	tok = strings.ReplaceAll(tok, "~", "~0")
+	tok = strings.ReplaceAll(tok, "/", "~1")
+	return tok
+}
@@ -0,0 +1,74 @@
+package fastjson
+
+import (
+	"testing"
+)
+
+func TestGetPointer(t *testing.T) {
+	v := MustParse(`{"foo":{"bar":[1,2,3]},"a~b":1,"c/d":2}`)
+
+	if n := v.GetPointer("/foo/bar/1").GetInt(); n != 2 {
+		t.Fatalf("unexpected value; got %d; want %d", n, 2)
+	}
+	if v.GetPointer("") != v {
+		t.Fatalf("empty pointer must address the whole document")
+	}
+	if v.GetPointer("/missing") != nil {
+		t.Fatalf("expected nil for missing path")
+	}
+	if n := v.GetPointer("/a~0b").GetInt(); n != 1 {
+		t.Fatalf("unexpected unescaped ~0 lookup; got %d; want %d", n, 1)
+	}
+	if n := v.GetPointer("/c~1d").GetInt(); n != 2 {
+		t.Fatalf("unexpected unescaped ~1 lookup; got %d; want %d", n, 2)
+	}
+}
+
+func TestSetPointer(t *testing.T) {
+	v := MustParse(`{"foo":{"bar":[1,2,3]}}`)
+
+	if err := v.SetPointer("/foo/bar/1", MustParse(`99`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := v.SetPointer("/foo/bar/-", MustParse(`4`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := v.SetPointer("/foo/baz", MustParse(`"new"`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	str := v.String()
+	strExpected := `{"foo":{"bar":[1,99,3,4],"baz":"new"}}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+
+	if err := v.SetPointer("/missing/x", MustParse(`1`)); err == nil {
+		t.Fatalf("expected error for missing parent path")
+	}
+}
+
+func TestDelPointer(t *testing.T) {
+	v := MustParse(`{"foo":{"bar":1,"baz":2}}`)
+	v.DelPointer("/foo/bar")
+	str := v.String()
+	strExpected := `{"foo":{"baz":2}}`
+	if str != strExpected {
+		t.Fatalf("unexpected result\ngot\n%s\nwant\n%s", str, strExpected)
+	}
+
+	// No-op for malformed or missing paths.
+	v.DelPointer("missing-leading-slash")
+	v.DelPointer("/does/not/exist")
+}
+
+func TestPointerCompiled(t *testing.T) {
+	p := MustParsePointer("/foo/0")
+	v := MustParse(`{"foo":["a","b"]}`)
+	if s, _ := p.Get(v).StringBytes(); string(s) != "a" {
+		t.Fatalf("unexpected value; got %q; want %q", s, "a")
+	}
+
+	if _, err := ParsePointer("no-leading-slash"); err == nil {
+		t.Fatalf("expected error for pointer without leading slash")
+	}
+}